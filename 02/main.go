@@ -3,12 +3,10 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"intcodecomputer"
-	"io/ioutil"
 	"log"
-	"strconv"
-	"strings"
 )
 
 func main() {
@@ -22,10 +20,13 @@ func partOne() {
 	fmt.Println("Part 1 start")
 
 	setupInstructionsFromFile()
-	instructions[1] = 12
-	instructions[2] = 2
-	icc := intcodecomputer.NewIntCodeComputer(instructions, false, "computer")
-	icc.Run()
+	program := append([]int64{}, instructions...)
+	program[1] = 12
+	program[2] = 2
+	icc := intcodecomputer.NewIntCodeComputer(program, intcodecomputer.WithCoreDumpFromEnv())
+	if err := icc.Run(); err != nil {
+		log.Fatal(err)
+	}
 	ok, value := icc.GetInstruction(0)
 	if !ok {
 		log.Fatal("0 out of range")
@@ -50,43 +51,28 @@ func partTwo() {
 }
 
 func findNounAndVerb(output int64) (bool, int, int) {
-	icc := intcodecomputer.NewIntCodeComputer(instructions, false, "computer")
-	for noun := 0; noun < 100; noun++ {
-		for verb := 0; verb < 100; verb++ {
-			icc.Reset()
-			instructions[1] = int64(noun)
-			instructions[2] = int64(verb)
-			icc.UpdateInstructions(instructions)
-			icc.Run()
-
-			ok, value := icc.GetInstruction(0)
-			if !ok {
-				log.Fatal("0 out of range")
-			}
+	params := []intcodecomputer.Parameter{
+		{Address: 1, Min: 0, Max: 99},
+		{Address: 2, Min: 0, Max: 99},
+	}
+	producesOutput := func(variant intcodecomputer.Variant) bool {
+		return variant.Err == nil && variant.Memory[0] == output
+	}
 
-			if value == output {
-				return true, noun, verb
-			}
-		}
+	variant, found, err := intcodecomputer.SweepFirst(context.Background(), instructions, params, producesOutput, intcodecomputer.SweepOptions{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !found {
+		return false, 0, 0
 	}
-	return false, 0, 0
+	return true, int(variant.Values[0]), int(variant.Values[1])
 }
 
 func setupInstructionsFromFile() {
-	instructions = nil
-	content, err := ioutil.ReadFile("./input")
+	var err error
+	instructions, err = intcodecomputer.Load("./input")
 	if err != nil {
 		log.Fatal(err)
 	}
-
-	text := string(content)
-	inputAsStrings := strings.Split(text, ",")
-
-	for _, str := range inputAsStrings {
-		input, e := strconv.ParseInt(str, 0, 0)
-		if e != nil {
-			log.Fatal(err)
-		}
-		instructions = append(instructions, input)
-	}
 }
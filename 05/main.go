@@ -5,10 +5,7 @@ package main
 import (
 	"fmt"
 	"intcodecomputer"
-	"io/ioutil"
 	"log"
-	"strconv"
-	"strings"
 )
 
 func main() {
@@ -19,36 +16,27 @@ func main() {
 func partOne() {
 	fmt.Println("Part 1 start")
 	instructions := getInstructionsFromFile()
-	computer := intcodecomputer.NewIntCodeComputer(instructions, false, "computer")
+	computer := intcodecomputer.NewIntCodeComputer(instructions, intcodecomputer.WithCoreDumpFromEnv())
 	computer.UpdateInputs([]int64{1})
-	computer.Run()
+	if err := computer.Run(); err != nil {
+		log.Fatal(err)
+	}
 }
 
 func partTwo() {
 	fmt.Println("Part 2 start")
 	instructions := getInstructionsFromFile()
-	computer := intcodecomputer.NewIntCodeComputer(instructions, false, "computer")
+	computer := intcodecomputer.NewIntCodeComputer(instructions, intcodecomputer.WithCoreDumpFromEnv())
 	computer.UpdateInputs([]int64{5})
-	computer.Run()
+	if err := computer.Run(); err != nil {
+		log.Fatal(err)
+	}
 }
 
 func getInstructionsFromFile() []int64 {
-	var instructions []int64
-	content, err := ioutil.ReadFile("./input")
+	instructions, err := intcodecomputer.Load("./input")
 	if err != nil {
 		log.Fatal(err)
 	}
-
-	text := string(content)
-	inputAsStrings := strings.Split(text, ",")
-
-	for i := range inputAsStrings {
-		input, e := strconv.ParseInt(inputAsStrings[i], 0, 0)
-		if e != nil {
-			log.Fatal(e)
-		}
-		instructions = append(instructions, input)
-	}
-
 	return instructions
 }
@@ -5,13 +5,10 @@ package main
 import (
 	"fmt"
 	"intcodecomputer"
-	"io/ioutil"
 	"log"
 	"strconv"
-	"strings"
 )
 
-var amplifiers []*intcodecomputer.IntCodeComputer
 var instructions []int64
 
 func main() {
@@ -23,7 +20,6 @@ func partOne() {
 	fmt.Println("Part 1 start")
 	instructions = getInstructionsFromFile()
 	phaseSettings := []int{4, 3, 2, 1, 0}
-	setupAmplifiers(false, len(phaseSettings))
 	permutations := createAllPhaseSettingPermutations(phaseSettings)
 	maxOutput, maxPhaseSettings := findLargestThrustersOutputSignal(permutations, false)
 	fmt.Println("Largest output signal sent to thrusters:", maxOutput, "with phaseSettings:", maxPhaseSettings)
@@ -33,20 +29,11 @@ func partTwo() {
 	fmt.Println("Part 2 start")
 	instructions = getInstructionsFromFile()
 	phaseSettings := []int{5, 6, 7, 8, 9}
-	setupAmplifiers(true, len(phaseSettings))
 	permutations := createAllPhaseSettingPermutations(phaseSettings)
 	maxOutput, maxPhaseSettings := findLargestThrustersOutputSignal(permutations, true)
 	fmt.Println("Largest output signal sent to thrusters:", maxOutput, "with phaseSettings:", maxPhaseSettings)
 }
 
-func setupAmplifiers(shouldPauseOnOutput bool, numOfAmplifiers int) {
-	amplifiers = nil
-	for i := 0; i < numOfAmplifiers; i++ {
-		icc := intcodecomputer.NewIntCodeComputer(instructions, shouldPauseOnOutput, "amp"+strconv.Itoa(i))
-		amplifiers = append(amplifiers, icc)
-	}
-}
-
 func createAllPhaseSettingPermutations(phaseSettings []int) *[][]int {
 	permutations := [][]int{}
 	permute(phaseSettings, 0, &permutations)
@@ -82,14 +69,14 @@ func addPermutation(permutations *[][]int, phases []int) {
 func findLargestThrustersOutputSignal(permutations *[][]int, useFeedbackLoop bool) (int64, []int) {
 	maxOutputSignal := int64(0)
 	maxPhaseSettings := []int{}
-	amplifierRunFunc := runAllAmplifiersOnce
-	if useFeedbackLoop {
-		amplifierRunFunc = runAllAmplifiersWithFeedbackLoop
-	}
 	for _, phaseSettings := range *permutations {
-		resetAllAmplifiers()
-		amplifierRunFunc(phaseSettings)
-		output := amplifiers[len(amplifiers)-1].GetOutput()
+		names := createAmplifierNames(len(phaseSettings))
+		topology := createAmplifierTopology(names, phaseSettings, useFeedbackLoop)
+		result, err := topology.Run()
+		if err != nil {
+			log.Fatal(err)
+		}
+		output, _ := result.LastOutput(names[len(names)-1])
 		if output > maxOutputSignal {
 			fmt.Println("MAX", output)
 			maxOutputSignal = output
@@ -100,71 +87,33 @@ func findLargestThrustersOutputSignal(permutations *[][]int, useFeedbackLoop boo
 	return maxOutputSignal, maxPhaseSettings
 }
 
-func runAllAmplifiersOnce(phaseSettings []int) {
-	for i, phase := range phaseSettings {
-		prevAmpIndex := mod(i-1, len(phaseSettings))
-		amplifiers[i].UpdateInputs([]int64{int64(phase), amplifiers[prevAmpIndex].GetOutput()})
-		amplifiers[i].Run()
-	}
-}
-
-func runAllAmplifiersWithFeedbackLoop(phaseSettings []int) {
-	for i, phase := range phaseSettings {
-		prevAmpIndex := mod(i-1, len(phaseSettings))
-		amplifiers[i].UpdateInputs([]int64{int64(phase), amplifiers[prevAmpIndex].GetOutput()})
-		amplifiers[i].Run()
-	}
-
-	for !haveAllAmplifiersFinished() {
-		for i := range amplifiers {
-			if amplifiers[i].IsHalted() {
-				continue
-			}
-
-			prevAmpIndex := mod(i-1, len(amplifiers))
-			amplifiers[i].UpdateInputs([]int64{amplifiers[prevAmpIndex].GetOutput()})
-			amplifiers[i].Resume()
-		}
+func createAmplifierNames(numOfAmplifiers int) []string {
+	names := make([]string, numOfAmplifiers)
+	for i := range names {
+		names[i] = "amp" + strconv.Itoa(i)
 	}
+	return names
 }
 
-func haveAllAmplifiersFinished() bool {
-	for i := 0; i < len(amplifiers); i++ {
-		if !amplifiers[i].IsHalted() {
-			return false
-		}
+func createAmplifierTopology(names []string, phaseSettings []int, useFeedbackLoop bool) *intcodecomputer.Topology {
+	topology := intcodecomputer.NewTopology()
+	for i, name := range names {
+		topology.AddNode(name, instructions).Seed(name, int64(phaseSettings[i]))
 	}
-	return true
-}
+	topology.Seed(names[0], int64(phaseSettings[0]), 0)
 
-func resetAllAmplifiers() {
-	for i := range amplifiers {
-		amplifiers[i].Reset()
-		amplifiers[i].UpdateInstructions(instructions)
+	if useFeedbackLoop {
+		topology.Ring(names...)
+	} else {
+		topology.Chain(names...)
 	}
+	return topology
 }
 
 func getInstructionsFromFile() []int64 {
-	content, err := ioutil.ReadFile("./input")
+	instr, err := intcodecomputer.Load("./input")
 	if err != nil {
 		log.Fatal(err)
 	}
-
-	text := string(content)
-	inputAsStrings := strings.Split(text, ",")
-
-	var instr []int64
-	for i := range inputAsStrings {
-		input, e := strconv.ParseInt(inputAsStrings[i], 0, 0)
-		if e != nil {
-			log.Fatal(e)
-		}
-		instr = append(instr, input)
-	}
-
 	return instr
 }
-
-func mod(a, b int) int {
-	return (a%b + b) % b
-}
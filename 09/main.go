@@ -5,10 +5,7 @@ package main
 import (
 	"fmt"
 	"intcodecomputer"
-	"io/ioutil"
 	"log"
-	"strconv"
-	"strings"
 )
 
 var amplifiers []*intcodecomputer.IntCodeComputer
@@ -32,32 +29,25 @@ func partTwo() {
 }
 
 func runBoostProgramTest() {
-	icc := intcodecomputer.NewIntCodeComputer(instructions, false, "computer")
+	icc := intcodecomputer.NewIntCodeComputer(instructions, intcodecomputer.WithCoreDumpFromEnv())
 	icc.UpdateInputs([]int64{1})
-	icc.Run()
+	if err := icc.Run(); err != nil {
+		log.Fatal(err)
+	}
 }
 
 func runBoostProgramInSensorBoostMode() {
-	icc := intcodecomputer.NewIntCodeComputer(instructions, false, "computer")
+	icc := intcodecomputer.NewIntCodeComputer(instructions, intcodecomputer.WithCoreDumpFromEnv())
 	icc.UpdateInputs([]int64{2})
-	icc.Run()
+	if err := icc.Run(); err != nil {
+		log.Fatal(err)
+	}
 }
 
 func setupInstructionsFromFile() {
-	content, err := ioutil.ReadFile("./input")
+	var err error
+	instructions, err = intcodecomputer.Load("./input")
 	if err != nil {
 		log.Fatal(err)
 	}
-
-	text := string(content)
-	inputAsStrings := strings.Split(text, ",")
-
-	instructions = nil
-	for i := range inputAsStrings {
-		input, e := strconv.ParseInt(inputAsStrings[i], 0, 0)
-		if e != nil {
-			log.Fatal(e)
-		}
-		instructions = append(instructions, input)
-	}
 }
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"intcodecomputer"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var coreCommands = map[string]func(args []string) error{
+	"inspect": runCoreInspect,
+}
+
+func runCore(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: intcode core inspect [flags] <core>")
+		return errWrongNumberOfArguments
+	}
+	run, ok := coreCommands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown core command %q", args[0])
+	}
+	return run(args[1:])
+}
+
+func runCoreInspect(args []string) error {
+	fs := newFlagSet("core inspect", "<core>")
+	context := fs.Int("context", 16, "disassemble `n` words of memory before and after the faulting address")
+	memoryRange := fs.String("memory", "", "disassemble the memory `from:to` instead of the words around the faulting address, for example \"0:100\" or \"0:\" for all of it")
+	maxOutputs := fs.Int("outputs", 20, "show the last `n` outputs, or all of them if n is negative")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errWrongNumberOfArguments
+	}
+
+	core, err := intcodecomputer.LoadCoreDump(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	from, to := core.Address-*context, core.Address+*context
+	if *memoryRange != "" {
+		if from, to, err = parseRange(*memoryRange, len(core.Memory)); err != nil {
+			return err
+		}
+	}
+	if from < 0 {
+		from = 0
+	}
+	if to >= len(core.Memory) {
+		to = len(core.Memory) - 1
+	}
+
+	fault := core.Fault
+	if fault == "" {
+		fault = "none"
+	}
+	fmt.Printf("computer:      %s\n", core.Name)
+	fmt.Printf("fault:         %s\n", fault)
+	fmt.Printf("address:       %d\n", core.Address)
+	fmt.Printf("relative base: %d\n", core.RelativeBase)
+	fmt.Printf("steps:         %d\n", core.Steps)
+	fmt.Printf("memory:        %d words\n", len(core.Memory))
+	fmt.Printf("pending input: %v\n", core.PendingInputs)
+
+	outputs := core.Outputs
+	if *maxOutputs >= 0 && len(outputs) > *maxOutputs {
+		outputs = outputs[len(outputs)-*maxOutputs:]
+	}
+	fmt.Printf("\noutputs (%d, showing the last %d):\n", core.OutputCount, len(outputs))
+	for _, output := range outputs {
+		fmt.Printf("  %d\n", output)
+	}
+
+	fmt.Printf("\nlast %d instructions:\n", len(core.Trace))
+	for _, traced := range core.Trace {
+		fmt.Printf("  step %-8d rb %-6d %s\n", traced.Step, traced.RelativeBase, traced.Disassemble())
+	}
+
+	fmt.Printf("\nmemory %d to %d:\n", from, to)
+	for _, line := range intcodecomputer.Disassemble(core.Memory) {
+		if line.Address+len(line.Words) <= from || line.Address > to {
+			continue
+		}
+		marker := "  "
+		if line.Address == core.Address {
+			marker = "=>"
+		}
+		fmt.Printf("%s %s\n", marker, line)
+	}
+	return nil
+}
+
+//parseRange parses "from:to", where an empty from or to means the start or the end of a memory of size words.
+func parseRange(s string, size int) (int, int, error) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid range %q, expected from:to", s)
+	}
+	from, to := 0, size-1
+	var err error
+	if parts[0] != "" {
+		if from, err = strconv.Atoi(parts[0]); err != nil {
+			return 0, 0, fmt.Errorf("invalid range %q: %v", s, err)
+		}
+	}
+	if parts[1] != "" {
+		if to, err = strconv.Atoi(parts[1]); err != nil {
+			return 0, 0, fmt.Errorf("invalid range %q: %v", s, err)
+		}
+	}
+	return from, to, nil
+}
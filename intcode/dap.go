@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+	"intcodecomputer/dap"
+	"net"
+	"os"
+)
+
+func runDAP(args []string) error {
+	fs := newFlagSet("dap", "")
+	listen := fs.String("listen", "", "accept debug clients on the loopback TCP `address`, for example 127.0.0.1:4711, instead of using stdin and stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return errWrongNumberOfArguments
+	}
+
+	if *listen == "" {
+		return dap.Serve(os.Stdin, os.Stdout)
+	}
+	return dap.ListenAndServe(*listen, func(addr net.Addr) {
+		fmt.Fprintln(os.Stderr, "intcode: debug adapter listening on", addr)
+	})
+}
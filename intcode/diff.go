@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"intcodecomputer"
+	"intcodecomputer/diff"
+	"io/ioutil"
+	"strings"
+)
+
+func runDiff(args []string) error {
+	fs := newFlagSet("diff", "<a> <b>")
+	summaryOnly := fs.Bool("summary", false, "only list the changed regions")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return errWrongNumberOfArguments
+	}
+
+	a, err := loadMemory(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	b, err := loadMemory(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	regions := diff.Compare(a, b)
+
+	numOfChanges := 0
+	for _, region := range regions {
+		numOfChanges += len(region.Changes)
+	}
+	fmt.Printf("--- %s (%d words)\n+++ %s (%d words)\n", fs.Arg(0), len(a), fs.Arg(1), len(b))
+	fmt.Printf("%d words differ in %d regions\n", numOfChanges, len(regions))
+
+	if !*summaryOnly {
+		for _, region := range regions {
+			fmt.Printf("\n@@ %s @@\n", region)
+			lastA, lastB := -1, -1
+			for _, c := range region.Changes {
+				lineA, lineB := formatLine(c.LineA, c.InA, lastA), formatLine(c.LineB, c.InB, lastB)
+				lastA, lastB = c.LineA.Address, c.LineB.Address
+				row := fmt.Sprintf("%8d  %14s  %14s  %-32s  %s", c.Address, formatWord(c.A, c.InA), formatWord(c.B, c.InB), lineA, lineB)
+				fmt.Println(strings.TrimRight(row, " "))
+			}
+		}
+		fmt.Println()
+	}
+	for _, region := range regions {
+		fmt.Println(region)
+	}
+	return nil
+}
+
+//loadMemory reads the memory of a JSON file with a "memory" field, such as a core file or a play session, or else a program in any format accepted by intcodecomputer.Load.
+func loadMemory(path string) ([]int64, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(content), []byte("{")) {
+		return intcodecomputer.Load(path)
+	}
+	var dump struct {
+		Memory []int64 `json:"memory"`
+	}
+	if err := json.Unmarshal(content, &dump); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if dump.Memory == nil {
+		return nil, fmt.Errorf("%s: JSON file without a \"memory\" field", path)
+	}
+	return dump.Memory, nil
+}
+
+func formatWord(word int64, ok bool) string {
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprint(word)
+}
+
+//formatLine formats the instruction a changed word belongs to, unless it was already shown for the previous word, which belongs to the instruction at lastAddress.
+func formatLine(line intcodecomputer.AsmLine, ok bool, lastAddress int) string {
+	switch {
+	case !ok:
+		return "-"
+	case line.Address == lastAddress:
+		return ""
+	}
+	return line.String()
+}
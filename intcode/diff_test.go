@@ -0,0 +1,36 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadMemory(t *testing.T) {
+	tests := []struct {
+		content string
+		want    []int64
+	}{
+		{`{"memory": [1, 2, 99]}`, []int64{1, 2, 99}},
+		{`{"memory": []}`, []int64{}},
+		{"1,2,99\n", []int64{1, 2, 99}},
+		{`{"version": 1, "events": []}`, nil},
+	}
+	path := filepath.Join(t.TempDir(), "memory")
+	for _, test := range tests {
+		if err := ioutil.WriteFile(path, []byte(test.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		got, err := loadMemory(path)
+		if test.want == nil {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %v", test.content, got)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: expected %v, got %v and %v", test.content, test.want, got, err)
+		}
+	}
+}
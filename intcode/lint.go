@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"intcodecomputer"
+	"intcodecomputer/lint"
+	"os"
+)
+
+func runLint(args []string) error {
+	fs := newFlagSet("lint", "<program>")
+	asJSON := fs.Bool("json", false, "print the findings as JSON")
+	minSeverity := fs.String("severity", "info", "only report findings of at least this `severity`: info, warning or error")
+	isa := fs.String("isa", "day9", "report instructions outside the instruction set `level`: day2, day5 or day9")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errWrongNumberOfArguments
+	}
+	threshold, err := lint.ParseSeverity(*minSeverity)
+	if err != nil {
+		return err
+	}
+	level, err := intcodecomputer.ParseISALevel(*isa)
+	if err != nil {
+		return err
+	}
+
+	program, err := intcodecomputer.Load(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	findings := []lint.Finding{}
+	numOfErrors := 0
+	for _, finding := range lint.LintForISA(program, level) {
+		if finding.Severity < threshold {
+			continue
+		}
+		findings = append(findings, finding)
+		if finding.Severity == lint.Error {
+			numOfErrors++
+		}
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(map[string]interface{}{"program": fs.Arg(0), "findings": findings}); err != nil {
+			return err
+		}
+	} else {
+		for _, finding := range findings {
+			fmt.Printf("%s:%s\n", fs.Arg(0), finding)
+		}
+	}
+	if numOfErrors > 0 {
+		return fmt.Errorf("%d findings with severity error", numOfErrors)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+//Command intcode runs and inspects Intcode programs.
+//
+//Usage:
+//
+//	intcode <command> [arguments]
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+)
+
+//errWrongNumberOfArguments is returned when a command is called with the wrong number of arguments, after its usage has been shown.
+var errWrongNumberOfArguments = errors.New("wrong number of arguments")
+
+type command struct {
+	run     func(args []string) error
+	summary string
+}
+
+var commands = map[string]command{
+	"core":     {runCore, "inspect the core files written by programs that faulted"},
+	"dap":      {runDAP, "serve the Debug Adapter Protocol on stdio or TCP"},
+	"diff":     {runDiff, "compare two programs or memory dumps word by word"},
+	"lint":     {runLint, "report likely bugs in a program without running it"},
+	"optimize": {runOptimize, "rewrite a program into an equivalent faster program"},
+	"play":     {runPlay, "run a program interactively, wired to the terminal"},
+	"serve":    {runServe, "serve an HTTP/JSON API for running programs on localhost"},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintln(os.Stderr, "intcode: unknown command", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	if err := cmd.run(os.Args[2:]); err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(2)
+		}
+		fmt.Fprintln(os.Stderr, "intcode:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: intcode <command> [arguments]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", name, commands[name].summary)
+	}
+}
+
+//newFlagSet creates the flag set of a command. Errors are returned from Parse rather than exiting.
+func newFlagSet(name string, arguments string) *flag.FlagSet {
+	fs := flag.NewFlagSet("intcode "+name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: intcode %s [flags] %s\n", name, arguments)
+		fs.PrintDefaults()
+	}
+	return fs
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"intcodecomputer"
+	"intcodecomputer/optimize"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+func runOptimize(args []string) error {
+	fs := newFlagSet("optimize", "<program>")
+	outPath := fs.String("o", "", "write the optimized program to `file` instead of stdout")
+	mapPath := fs.String("map", "", "write the address mapping table to `file`, one \"old new\" pair per line")
+	samples := fs.String("verify", "", "run both programs on the `inputs`, lists of integers separated by semicolons, for example \"1;5\" or \";\" for two runs without input")
+	stepLimit := fs.Int("step-limit", 10000000, "fail verification of programs that run more than `n` instructions")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errWrongNumberOfArguments
+	}
+
+	program, err := intcodecomputer.Load(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	result := optimize.Optimize(program)
+	if result.Refused != "" {
+		fmt.Fprintln(os.Stderr, "intcode: not optimized:", result.Refused)
+	}
+	for _, change := range result.Changes {
+		fmt.Fprintln(os.Stderr, change)
+	}
+	fmt.Fprintf(os.Stderr, "%d words, %d changes\n", len(result.Program), len(result.Changes))
+
+	if *samples != "" {
+		var inputs [][]int64
+		for _, sample := range strings.Split(*samples, ";") {
+			values := []int64{}
+			if strings.TrimSpace(sample) != "" {
+				var err error
+				if values, err = parseInts(sample); err != nil {
+					return err
+				}
+			}
+			inputs = append(inputs, values)
+		}
+		verified, err := optimize.Verify(program, result.Program, inputs, *stepLimit)
+		for _, sample := range verified {
+			fmt.Fprintf(os.Stderr, "verified %v: outputs %v in %d steps, %d before\n", sample.Inputs, sample.Outputs, sample.OptimizedSteps, sample.OriginalSteps)
+		}
+		if err != nil {
+			return fmt.Errorf("verification failed: %v", err)
+		}
+	}
+
+	if *mapPath != "" {
+		var b strings.Builder
+		for old, address := range result.AddressMap {
+			fmt.Fprintln(&b, old, address)
+		}
+		if err := ioutil.WriteFile(*mapPath, []byte(b.String()), 0644); err != nil {
+			return err
+		}
+	}
+
+	text := intcodecomputer.Format(result.Program) + "\n"
+	if *outPath != "" {
+		return ioutil.WriteFile(*outPath, []byte(text), 0644)
+	}
+	w := bufio.NewWriter(os.Stdout)
+	w.WriteString(text)
+	return w.Flush()
+}
@@ -0,0 +1,392 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"intcodecomputer"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	modeAuto  = "auto"
+	modeASCII = "ascii"
+	modeInt   = "int"
+)
+
+//playSession is the file format of /save and /load. It holds the whole state of the computer, so that a loaded session continues exactly where it was saved.
+type playSession struct {
+	Mode              string   `json:"mode"`
+	History           []string `json:"history"`
+	Pending           []int64  `json:"pending"`
+	Memory            []int64  `json:"memory"`
+	Address           int      `json:"address"`
+	RelativeBase      int64    `json:"relativeBase"`
+	Inputs            []int64  `json:"inputs"`
+	CurrentInputIndex int      `json:"currentInputIndex"`
+	Output            int64    `json:"output"`
+	Steps             int      `json:"steps"`
+	IsPaused          bool     `json:"isPaused"`
+	IsHalted          bool     `json:"isHalted"`
+	IsWaitingForInput bool     `json:"isWaitingForInput"`
+	LastOutput        int64    `json:"lastOutput"`
+	HasOutput         bool     `json:"hasOutput"`
+}
+
+type player struct {
+	icc        *intcodecomputer.IntCodeComputer
+	mode       string
+	pending    []int64
+	history    []string
+	out        io.Writer
+	transcript io.Writer
+	lastOutput int64
+	hasOutput  bool
+}
+
+var playCommands = map[string]func(p *player, arg string) error{
+	"help":    (*player).help,
+	"history": (*player).showHistory,
+	"redo":    (*player).redo,
+	"save":    (*player).save,
+	"load":    (*player).load,
+	"mode":    (*player).setMode,
+}
+
+func runPlay(args []string) error {
+	fs := newFlagSet("play", "<program>")
+	mode := fs.String("mode", modeAuto, "input and output mode: ascii, int or auto")
+	transcriptPath := fs.String("transcript", "", "append everything shown and typed to `file`")
+	sessionPath := fs.String("session", "", "restore a session saved with /save from `file`")
+	recordPath := fs.String("record", "", "record every input and output to the session `file`, for replaying in a test")
+	corePath := fs.String("core", "", "write a core `file` if the program faults, for intcode core inspect")
+	verbose := fs.Bool("v", false, "log every instruction's input and output to stderr")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errWrongNumberOfArguments
+	}
+	if *mode != modeAuto && *mode != modeASCII && *mode != modeInt {
+		return fmt.Errorf("unknown mode %q", *mode)
+	}
+	if *recordPath != "" && *sessionPath != "" {
+		return errors.New("-record cannot be used with -session, since a recording starts from the beginning of the program")
+	}
+
+	instructions, err := intcodecomputer.Load(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	p := newPlayer(intcodecomputer.NewIntCodeComputer(instructions, intcodecomputer.WithName("play"), intcodecomputer.WithCoreDump(*corePath, intcodecomputer.DefaultCoreTraceLength)), *mode, os.Stdout)
+	if *transcriptPath != "" {
+		f, err := os.OpenFile(*transcriptPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		p.transcript = f
+		p.out = io.MultiWriter(os.Stdout, f)
+	}
+	if *verbose {
+		p.icc.SetLogWriter(os.Stderr)
+	} else {
+		p.icc.SetLogWriter(nil)
+	}
+
+	if *sessionPath != "" {
+		if err := p.load(*sessionPath); err != nil {
+			return err
+		}
+	}
+
+	if *recordPath == "" {
+		return p.play(os.Stdin)
+	}
+	recorder := intcodecomputer.NewRecorder(p.icc)
+	err = p.play(os.Stdin)
+	if saveErr := recorder.Save(*recordPath); saveErr != nil && err == nil {
+		err = saveErr
+	}
+	return err
+}
+
+//newPlayer creates a player that feeds the lines it reads to icc and shows the outputs of icc on out.
+func newPlayer(icc *intcodecomputer.IntCodeComputer, mode string, out io.Writer) *player {
+	p := player{icc: icc, mode: mode, out: out, transcript: ioutil.Discard}
+	icc.SetInputProvider(p.nextInput)
+	icc.SetOutputHandler(p.showOutput)
+	return &p
+}
+
+func (p *player) play(in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	for {
+		var err error
+		if p.icc.IsPaused() {
+			err = p.icc.Resume()
+		} else {
+			err = p.icc.Run()
+		}
+		if err != nil {
+			p.finishLine()
+			return err
+		}
+		if p.icc.IsHalted() {
+			p.finishLine()
+			fmt.Fprintln(p.out, "[program halted]")
+			return nil
+		}
+
+		if p.mode != modeASCII {
+			fmt.Fprint(p.out, "> ")
+		}
+		if !scanner.Scan() {
+			p.finishLine()
+			return scanner.Err()
+		}
+		line := scanner.Text()
+		fmt.Fprintln(p.transcript, line)
+
+		if strings.HasPrefix(line, "/") {
+			if err := p.runCommand(line[1:]); err != nil {
+				if err == io.EOF {
+					return nil
+				}
+				fmt.Fprintln(p.out, "error:", err)
+			}
+			continue
+		}
+		if err := p.send(line); err != nil {
+			fmt.Fprintln(p.out, "error:", err)
+		}
+	}
+}
+
+func (p *player) runCommand(line string) error {
+	name, arg := line, ""
+	if i := strings.IndexByte(line, ' '); i >= 0 {
+		name, arg = line[:i], strings.TrimSpace(line[i+1:])
+	}
+	if name == "quit" {
+		return io.EOF
+	}
+	cmd, ok := playCommands[name]
+	if !ok {
+		return fmt.Errorf("unknown command /%s, see /help", name)
+	}
+	return cmd(p, arg)
+}
+
+//send queues line as input and records it in the history.
+func (p *player) send(line string) error {
+	if p.mode == modeAuto {
+		p.mode = modeASCII
+		if _, err := parseInts(line); err == nil {
+			p.mode = modeInt
+		}
+	}
+
+	if p.mode == modeInt {
+		values, err := parseInts(line)
+		if err != nil {
+			return err
+		}
+		p.pending = append(p.pending, values...)
+	} else {
+		for _, b := range []byte(line) {
+			p.pending = append(p.pending, int64(b))
+		}
+		p.pending = append(p.pending, '\n')
+	}
+	p.history = append(p.history, line)
+	return nil
+}
+
+func (p *player) nextInput() (int64, bool) {
+	if len(p.pending) == 0 {
+		return 0, false
+	}
+	value := p.pending[0]
+	p.pending = p.pending[1:]
+	return value, true
+}
+
+func (p *player) showOutput(value int64) {
+	isASCII := 0 <= value && value <= 127
+	if p.mode == modeAuto {
+		p.mode = modeInt
+		if isASCII {
+			p.mode = modeASCII
+		}
+	}
+
+	if p.mode == modeASCII && isASCII {
+		fmt.Fprint(p.out, string(rune(value)))
+	} else {
+		p.finishLine()
+		fmt.Fprintln(p.out, value)
+	}
+	p.lastOutput = value
+	p.hasOutput = true
+}
+
+//finishLine ends the current line of ASCII output, so that what follows starts on a new line.
+func (p *player) finishLine() {
+	if p.mode == modeASCII && p.hasOutput && p.lastOutput != '\n' {
+		fmt.Fprintln(p.out)
+		p.lastOutput = '\n'
+	}
+}
+
+func (p *player) help(arg string) error {
+	fmt.Fprintln(p.out, "Lines are sent to the program as input. Commands:")
+	fmt.Fprintln(p.out, "  /history        list the lines sent so far")
+	fmt.Fprintln(p.out, "  /history <n>    list the last n lines")
+	fmt.Fprintln(p.out, "  /history <text> list the lines containing text")
+	fmt.Fprintln(p.out, "  /redo [n]       send line n of the history again, the last line")
+	fmt.Fprintln(p.out, "                  without n, or the nth last line for negative n")
+	fmt.Fprintln(p.out, "  /save <file>    save the session")
+	fmt.Fprintln(p.out, "  /load <file>    restore a saved session")
+	fmt.Fprintln(p.out, "  /mode <mode>    switch between ascii and int input")
+	fmt.Fprintln(p.out, "  /quit           stop playing")
+	return nil
+}
+
+//showHistory lists the history with the line numbers /redo takes. A number as arg limits the list to the last lines, other text to the lines containing it.
+func (p *player) showHistory(arg string) error {
+	first, filter := 0, arg
+	if n, err := strconv.Atoi(arg); err == nil && n >= 0 {
+		filter = ""
+		if n < len(p.history) {
+			first = len(p.history) - n
+		}
+	}
+	for i := first; i < len(p.history); i++ {
+		if strings.Contains(p.history[i], filter) {
+			fmt.Fprintf(p.out, "%4d  %s\n", i+1, p.history[i])
+		}
+	}
+	return nil
+}
+
+//redo sends line n of the history again. Without n the last line is sent, and a negative n counts back from the last line.
+func (p *player) redo(arg string) error {
+	n := -1
+	if arg != "" {
+		var err error
+		if n, err = strconv.Atoi(arg); err != nil {
+			return fmt.Errorf("no history line %q", arg)
+		}
+	}
+	if n < 0 {
+		n += len(p.history) + 1
+	}
+	if n < 1 || n > len(p.history) {
+		return fmt.Errorf("no history line %q", arg)
+	}
+	line := p.history[n-1]
+	fmt.Fprintln(p.out, line)
+	return p.send(line)
+}
+
+func (p *player) setMode(arg string) error {
+	if arg != modeASCII && arg != modeInt {
+		return fmt.Errorf("unknown mode %q", arg)
+	}
+	p.mode = arg
+	return nil
+}
+
+func (p *player) save(path string) error {
+	if path == "" {
+		return errors.New("missing file name")
+	}
+	snapshot := p.icc.Snapshot()
+	session := playSession{
+		Mode:              p.mode,
+		History:           p.history,
+		Pending:           p.pending,
+		Memory:            snapshot.Instructions,
+		Address:           snapshot.Address,
+		RelativeBase:      snapshot.RelativeBase,
+		Inputs:            snapshot.Inputs,
+		CurrentInputIndex: snapshot.CurrentInputIndex,
+		Output:            snapshot.Output,
+		Steps:             snapshot.Steps,
+		IsPaused:          snapshot.IsPaused,
+		IsHalted:          snapshot.IsHalted,
+		IsWaitingForInput: snapshot.IsWaitingForInput,
+		LastOutput:        p.lastOutput,
+		HasOutput:         p.hasOutput,
+	}
+	content, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path, content, 0644); err != nil {
+		return err
+	}
+	fmt.Fprintln(p.out, "[session saved to", path+"]")
+	return nil
+}
+
+func (p *player) load(path string) error {
+	if path == "" {
+		return errors.New("missing file name")
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var session playSession
+	if err := json.Unmarshal(content, &session); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+
+	p.icc.Restore(intcodecomputer.Snapshot{
+		Instructions:      session.Memory,
+		Address:           session.Address,
+		RelativeBase:      session.RelativeBase,
+		Inputs:            session.Inputs,
+		CurrentInputIndex: session.CurrentInputIndex,
+		Output:            session.Output,
+		Steps:             session.Steps,
+		IsPaused:          session.IsPaused || session.IsWaitingForInput,
+		IsHalted:          session.IsHalted,
+		IsWaitingForInput: session.IsWaitingForInput,
+	})
+	p.mode = session.Mode
+	p.history = session.History
+	p.pending = session.Pending
+	p.lastOutput = session.LastOutput
+	p.hasOutput = session.HasOutput
+	fmt.Fprintln(p.out, "[session restored from", path+"]")
+	return nil
+}
+
+//parseInts parses a line of integers separated by commas or whitespace.
+func parseInts(line string) ([]int64, error) {
+	fields := strings.FieldsFunc(line, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	if len(fields) == 0 {
+		return nil, errors.New("no integers in line")
+	}
+	values := make([]int64, len(fields))
+	for i, field := range fields {
+		value, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
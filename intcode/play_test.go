@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"intcodecomputer"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//adder reads two integers and outputs their sum.
+var adder = []int64{3, 11, 3, 12, 1, 11, 12, 13, 4, 13, 99, 0, 0, 0}
+
+func newTestPlayer(program []int64) (*player, *bytes.Buffer) {
+	var out bytes.Buffer
+	icc := intcodecomputer.NewIntCodeComputer(program)
+	icc.SetLogWriter(nil)
+	return newPlayer(icc, modeAuto, &out), &out
+}
+
+func TestPlaySaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	first, _ := newTestPlayer(adder)
+	if err := first.play(strings.NewReader("5\n/save " + path + "\n/quit\n")); err != nil {
+		t.Fatal(err)
+	}
+	first.pending = []int64{6}
+	if err := first.save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	second, out := newTestPlayer(adder)
+	if err := second.load(path); err != nil {
+		t.Fatal(err)
+	}
+	if second.mode != modeInt || !reflect.DeepEqual(second.history, []string{"5"}) || !reflect.DeepEqual(second.pending, []int64{6}) {
+		t.Fatalf("expected int mode, history [5] and pending [6], got %s, %q and %v", second.mode, second.history, second.pending)
+	}
+	if want, got := first.icc.Snapshot(), second.icc.Snapshot(); !reflect.DeepEqual(want, got) {
+		t.Fatalf("expected the computer state %+v, got %+v", want, got)
+	}
+
+	if err := second.play(strings.NewReader("")); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "11\n[program halted]") {
+		t.Errorf("expected the sum 11 and a halt, got %q", out.String())
+	}
+
+	if err := second.save(path); err != nil {
+		t.Fatal(err)
+	}
+	third, _ := newTestPlayer(adder)
+	if err := third.load(path); err != nil {
+		t.Fatal(err)
+	}
+	if !third.icc.IsHalted() {
+		t.Error("expected the loaded computer to be halted")
+	}
+}
+
+func TestPlayHistory(t *testing.T) {
+	p, out := newTestPlayer([]int64{3, 5, 1105, 1, 0, 0})
+	if err := p.play(strings.NewReader("north\ntake key\nwest\n/redo\n/redo 1\n/redo -4\n/redo 9\n")); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"north", "take key", "west", "west", "north", "take key"}
+	if !reflect.DeepEqual(p.history, want) {
+		t.Errorf("expected history %q, got %q", want, p.history)
+	}
+	if !strings.Contains(out.String(), `error: no history line "9"`) {
+		t.Errorf("expected an error for line 9, got %q", out.String())
+	}
+
+	tests := map[string]string{
+		"":     "   1  north\n   2  take key\n   3  west\n   4  west\n   5  north\n   6  take key\n",
+		"2":    "   5  north\n   6  take key\n",
+		"ke":   "   2  take key\n   6  take key\n",
+		"west": "   3  west\n   4  west\n",
+	}
+	for arg, want := range tests {
+		out.Reset()
+		if err := p.showHistory(arg); err != nil || out.String() != want {
+			t.Errorf("/history %s: expected %q, got %q and %v", arg, want, out.String(), err)
+		}
+	}
+}
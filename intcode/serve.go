@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"intcodecomputer/service"
+	"net"
+	"net/http"
+	"os"
+)
+
+func runServe(args []string) error {
+	limits := service.DefaultLimits
+	fs := newFlagSet("serve", "")
+	addr := fs.String("addr", "127.0.0.1:8019", "listen on the TCP `address`")
+	fs.IntVar(&limits.MaxSessions, "max-sessions", limits.MaxSessions, "maximum number of sessions, 0 for no limit")
+	fs.IntVar(&limits.MaxPrograms, "max-programs", limits.MaxPrograms, "maximum number of uploaded programs, 0 for no limit")
+	fs.IntVar(&limits.MaxMemory, "max-memory", limits.MaxMemory, "memory limit of each session in words, 0 for the default of the computer")
+	fs.IntVar(&limits.MaxStepsPerRequest, "max-steps", limits.MaxStepsPerRequest, "maximum number of instructions executed per request")
+	fs.DurationVar(&limits.IdleTimeout, "idle-timeout", limits.IdleTimeout, "delete sessions that have not been used for this long, 0 to keep them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return errWrongNumberOfArguments
+	}
+
+	host, _, err := net.SplitHostPort(*addr)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		fmt.Fprintln(os.Stderr, "intcode: warning: serving on a non-loopback address; the API has no authentication")
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "intcode: serving on http://"+listener.Addr().String())
+	return http.Serve(listener, service.NewServer(limits))
+}